@@ -0,0 +1,26 @@
+// Command server runs the OMDb REST API.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/jesse-lucus/omdbapi/gomdb/server"
+	"github.com/jesse-lucus/omdbapi/gomdb/service"
+)
+
+func main() {
+	addr := flag.String("addr", ":8000", "address to listen on")
+	flag.Parse()
+
+	api, err := service.InitFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s := server.NewServer(api)
+
+	log.Printf("Listening for requests at http://localhost%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, s))
+}
@@ -0,0 +1,109 @@
+// Package server exposes the OMDb service client over a small JSON REST API.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jesse-lucus/omdbapi/gomdb/service"
+)
+
+// requestTimeout bounds how long a single incoming request may take to be
+// answered, including the outbound OMDb call.
+const requestTimeout = 10 * time.Second
+
+// Server wires an OMDb service.Client to a *http.ServeMux.
+type Server struct {
+	api *service.Client
+	mux *http.ServeMux
+}
+
+// NewServer builds a Server around api, ready to be used as an http.Handler.
+func NewServer(api *service.Client) *Server {
+	s := &Server{
+		api: api,
+		mux: http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/healthz", s.handleHealthz)
+	s.mux.HandleFunc("/search", s.handleSearch)
+	s.mux.HandleFunc("/detail/", s.handleDetail)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// errorEnvelope is the JSON body written for any failed request.
+type errorEnvelope struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	var apiErr *service.APIError
+	status := http.StatusInternalServerError
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	}
+	s.writeJSON(w, status, errorEnvelope{Error: err.Error()})
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		s.writeError(w, &service.APIError{StatusCode: http.StatusBadRequest, Message: "missing required query param \"name\""})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	query := &service.QueryData{
+		Title:      name,
+		Year:       r.URL.Query().Get("year"),
+		SearchType: service.MovieSearch,
+	}
+
+	resp, err := s.api.SearchContext(ctx, query)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleDetail(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Path[len("/detail/"):]
+	if id == "" {
+		s.writeError(w, &service.APIError{StatusCode: http.StatusBadRequest, Message: "missing imdb id in path"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.api.MovieByImdbIDContext(ctx, id)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache is the interface Client consults before issuing a network request.
+// Implementations are expected to be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached body for key and whether it was found and not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores body under key for the given ttl. A zero ttl means "never expires".
+	Set(key string, body []byte, ttl time.Duration)
+}
+
+type cacheEntry struct {
+	key       string
+	body      []byte
+	expiresAt time.Time
+}
+
+func (e *cacheEntry) expired() bool {
+	return !e.expiresAt.IsZero() && time.Now().After(e.expiresAt)
+}
+
+// MemoryCache is an in-memory LRU Cache implementation.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewMemoryCache creates a MemoryCache that holds at most capacity entries,
+// evicting the least recently used entry once that capacity is exceeded.
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity <= 0 {
+		capacity = 128
+	}
+	return &MemoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if entry.expired() {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.body, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, body []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl != 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		el.Value.(*cacheEntry).body = body
+		el.Value.(*cacheEntry).expiresAt = expiresAt
+		return
+	}
+
+	el := c.ll.PushFront(&cacheEntry{key: key, body: body, expiresAt: expiresAt})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*cacheEntry).key)
+		}
+	}
+}
+
+// FileCache is a filesystem-backed Cache implementation. Each entry is stored
+// as a file named after the sha1 of its key, with the expiry timestamp packed
+// into a fixed-size header so Get can reject stale entries without a second file.
+type FileCache struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileCache creates a FileCache rooted at dir, creating it if necessary.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".cache")
+}
+
+// Get implements Cache.
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	raw, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	if len(raw) < 8 {
+		return nil, false
+	}
+
+	expiresUnix := int64(0)
+	for i := 0; i < 8; i++ {
+		expiresUnix |= int64(raw[i]) << uint(8*i)
+	}
+	if expiresUnix != 0 && time.Now().Unix() > expiresUnix {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+	return raw[8:], true
+}
+
+// Set implements Cache.
+func (c *FileCache) Set(key string, body []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresUnix int64
+	if ttl != 0 {
+		expiresUnix = time.Now().Add(ttl).Unix()
+	}
+
+	header := make([]byte, 8)
+	for i := 0; i < 8; i++ {
+		header[i] = byte(expiresUnix >> uint(8*i))
+	}
+
+	ioutil.WriteFile(c.path(key), append(header, body...), 0o644)
+}
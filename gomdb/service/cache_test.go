@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheEviction(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	c.Set("a", []byte("a-body"), 0)
+	c.Set("b", []byte("b-body"), 0)
+
+	// Touch "a" so "b" becomes the least recently used entry.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected %q to be present before eviction", "a")
+	}
+
+	c.Set("c", []byte("c-body"), 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("expected %q to be evicted as least recently used", "b")
+	}
+	if body, ok := c.Get("a"); !ok || string(body) != "a-body" {
+		t.Errorf("expected %q to survive eviction, got %q, %v", "a", body, ok)
+	}
+	if body, ok := c.Get("c"); !ok || string(body) != "c-body" {
+		t.Errorf("expected %q to be present, got %q, %v", "c", body, ok)
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		wantHit bool
+	}{
+		{"zero ttl never expires", 0, true},
+		{"expired ttl", -time.Minute, false},
+		{"future ttl", time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMemoryCache(4)
+			c.Set("key", []byte("body"), tt.ttl)
+
+			_, ok := c.Get("key")
+			if ok != tt.wantHit {
+				t.Errorf("Get() hit = %v, want %v", ok, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestFileCacheExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		wantHit bool
+	}{
+		{"zero ttl never expires", 0, true},
+		{"expired ttl", -time.Minute, false},
+		{"future ttl", time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewFileCache(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewFileCache() error = %v", err)
+			}
+
+			c.Set("key", []byte("body"), tt.ttl)
+
+			body, ok := c.Get("key")
+			if ok != tt.wantHit {
+				t.Errorf("Get() hit = %v, want %v", ok, tt.wantHit)
+			}
+			if ok && string(body) != "body" {
+				t.Errorf("Get() body = %q, want %q", body, "body")
+			}
+		})
+	}
+}
+
+func TestFileCacheRoundTripsHeaderAndBody(t *testing.T) {
+	c, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache() error = %v", err)
+	}
+
+	// A body that happens to start with bytes resembling a header should
+	// still round-trip intact; the 8-byte expiry header must stay separate
+	// from the stored body.
+	want := []byte{0, 0, 0, 0, 0, 0, 0, 0, 1, 2, 3}
+	c.Set("key", want, time.Hour)
+
+	got, ok := c.Get("key")
+	if !ok {
+		t.Fatalf("expected cache hit")
+	}
+	if string(got) != string(want) {
+		t.Errorf("Get() body = %v, want %v", got, want)
+	}
+}
+
+func TestFileCacheMiss(t *testing.T) {
+	c, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache() error = %v", err)
+	}
+
+	if _, ok := c.Get("missing"); ok {
+		t.Errorf("expected miss for unset key")
+	}
+}
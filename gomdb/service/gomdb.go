@@ -1,273 +1,517 @@
-// Package gomdb is a golang implementation of the OMDB API.
-package main
-
-import (
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"net/url"
-	"os"
-
-	"github.com/joho/godotenv"
-)
-
-const (
-	baseURL  = "http://www.omdbapi.com/?"
-	plot     = "full"
-	tomatoes = "true"
-
-	MovieSearch   = "movie"
-	SeriesSearch  = "series"
-	EpisodeSearch = "episode"
-)
-
-type OmdbApi struct {
-	apiKey string
-}
-
-func Init() *OmdbApi {
-	apiKey := goDotEnvVariable("OMDB_API_KEY")
-	return &OmdbApi{apiKey: apiKey}
-}
-
-// QueryData is the type to create the search query
-type QueryData struct {
-	Title      string
-	Year       string
-	ImdbId     string
-	SearchType string
-}
-
-//SearchResult is the type for the search results
-type SearchResult struct {
-	Title  string
-	Year   string
-	ImdbID string
-	Type   string
-}
-
-//SearchResponse is the struct of the response in a search
-type SearchResponse struct {
-	Search       []SearchResult
-	Response     string
-	Error        string
-	totalResults int
-}
-
-//MovieResult is the result struct of an specific movie search
-type MovieResult struct {
-	Title             string
-	Year              string
-	Rated             string
-	Released          string
-	Runtime           string
-	Genre             string
-	Director          string
-	Writer            string
-	Actors            string
-	Plot              string
-	Language          string
-	Country           string
-	Awards            string
-	Poster            string
-	Metascore         string
-	ImdbRating        string
-	ImdbVotes         string
-	ImdbID            string
-	Type              string
-	TomatoMeter       string
-	TomatoImage       string
-	TomatoRating      string
-	TomatoReviews     string
-	TomatoFresh       string
-	TomatoRotten      string
-	TomatoConsensus   string
-	TomatoUserMeter   string
-	TomatoUserRating  string
-	TomatoUserReviews string
-	TomatoURL         string
-	DVD               string
-	BoxOffice         string
-	Production        string
-	Website           string
-	Response          string
-	Error             string
-}
-
-func goDotEnvVariable(key string) string {
-
-	// load .env file
-	err := godotenv.Load(".env")
-
-	if err != nil {
-		log.Fatalf("Error loading .env file")
-	}
-
-	return os.Getenv(key)
-}
-
-//Search for movies given a Title and year, Year is optional you can pass nil
-func (api *OmdbApi) Search(query *QueryData) (*SearchResponse, error) {
-	resp, err := api.requestAPI("search", query.Title, query.Year, query.SearchType)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	r := new(SearchResponse)
-	err = json.NewDecoder(resp.Body).Decode(r)
-
-	if err != nil {
-		return nil, err
-	}
-	if r.Response == "False" {
-		return r, errors.New(r.Error)
-	}
-
-	return r, nil
-}
-
-//MovieByTitle returns a MovieResult given Title
-func (api *OmdbApi) MovieByTitle(query *QueryData) (*MovieResult, error) {
-	resp, err := api.requestAPI("title", query.Title, query.Year, query.SearchType)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	r := new(MovieResult)
-	err = json.NewDecoder(resp.Body).Decode(r)
-
-	if err != nil {
-		return nil, err
-	}
-	if r.Response == "False" {
-		return r, errors.New(r.Error)
-	}
-	return r, nil
-}
-
-//MovieByImdbID returns a MovieResult given a ImdbID ex:"tt2015381"
-func (api *OmdbApi) MovieByImdbID(id string) (*MovieResult, error) {
-	resp, err := api.requestAPI("id", id)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	r := new(MovieResult)
-	err = json.NewDecoder(resp.Body).Decode(r)
-
-	if err != nil {
-		return nil, err
-	}
-	if r.Response == "False" {
-		return r, errors.New(r.Error)
-	}
-	return r, nil
-}
-
-// helper function to call the API
-// param: apiCategory refers to which API we are calling. Can be "search", "title" or "id"
-// Depending on that value, we will search by "t" or "s" or "i"
-// param: params are the variadic list of params passed for that category
-func (api *OmdbApi) requestAPI(apiCategory string, params ...string) (resp *http.Response, err error) {
-	var URL *url.URL
-	var urlAddress = ""
-	URL, err = url.Parse(baseURL)
-	if err != nil {
-		return nil, err
-	}
-
-	// Checking for invalid category
-	if len(params) > 1 && params[2] != "" {
-		if params[2] != MovieSearch &&
-			params[2] != SeriesSearch &&
-			params[2] != EpisodeSearch {
-			return nil, errors.New("Invalid search category- " + params[2])
-		}
-	}
-	URL.Path += "/"
-	parameters := url.Values{}
-	parameters.Add("apikey", api.apiKey)
-
-	switch apiCategory {
-	case "search":
-		parameters.Add("s", params[0])
-		parameters.Add("y", params[1])
-		parameters.Add("type", params[2])
-	case "title":
-		parameters.Add("t", params[0])
-		parameters.Add("y", params[1])
-		parameters.Add("type", params[2])
-		parameters.Add("plot", plot)
-		parameters.Add("tomatoes", tomatoes)
-	case "id":
-		parameters.Add("i", params[0])
-		parameters.Add("plot", plot)
-		parameters.Add("tomatoes", tomatoes)
-	}
-
-	URL.RawQuery = parameters.Encode()
-	urlAddress = URL.String()
-	res, err := http.Get(urlAddress)
-	err = checkErr(res.StatusCode)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
-}
-
-func checkErr(status int) error {
-	if status != 200 {
-		return fmt.Errorf("Status Code %d received from IMDB", status)
-	}
-	return nil
-}
-
-//Stringer Interface for MovieResult
-func (mr MovieResult) String() string {
-	return fmt.Sprintf("#%s: %s (%s)", mr.ImdbID, mr.Title, mr.Year)
-}
-
-//Stringer Interface for SearchResult
-func (sr SearchResult) String() string {
-	return fmt.Sprintf("#%s: %s (%s) Type: %s", sr.ImdbID, sr.Title, sr.Year, sr.Type)
-}
-
-func main() {
-	// Hello world, the web server
-
-	helloHandler := func(w http.ResponseWriter, req *http.Request) {
-		io.WriteString(w, "Hello, world!\n")
-	}
-
-	searchMovie := func(w http.ResponseWriter, req *http.Request) {
-		var title = req.URL.Query()["name"]
-		var year = req.URL.Query()["year"]
-		var query = &QueryData{Title: title[0], Year: year[0], SearchType: MovieSearch}
-		api := Init()
-		resp, _ := api.Search(query)
-		io.WriteString(w, fmt.Sprintf("%s", resp.Search))
-	}
-
-	findMovie := func(w http.ResponseWriter, req *http.Request) {
-		var id = req.URL.Query()["id"]
-		api := Init()
-		resp, _ := api.MovieByImdbID(id[0])
-		io.WriteString(w, fmt.Sprintf("%s", resp))
-	}
-
-	http.HandleFunc("/hello", helloHandler)
-	http.HandleFunc("/search", searchMovie)
-	http.HandleFunc("/detail/:id", findMovie)
-	log.Println("Listing for requests at http://localhost:8000")
-	log.Fatal(http.ListenAndServe(":8000", nil))
-}
-
-func Search(query *QueryData) {
-	panic("unimplemented")
-}
+// Package service is a golang implementation of the OMDB API.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	defaultBaseURL = "http://www.omdbapi.com/?"
+	plot           = "full"
+	tomatoes       = "true"
+
+	MovieSearch   = "movie"
+	SeriesSearch  = "series"
+	EpisodeSearch = "episode"
+
+	// defaultTTL is how long a successful response stays cached.
+	defaultTTL = 24 * time.Hour
+	// negativeTTL is how long a "Movie not found!" style response stays
+	// cached, short enough that a fixed typo is picked up quickly but long
+	// enough to stop repeated lookups of the same bad title/ID from
+	// burning through the daily OMDb quota.
+	negativeTTL = 5 * time.Minute
+)
+
+// Client is an OMDb API client. Use NewClient or InitFromEnv to build one;
+// the zero value is not usable since it has no API key.
+type Client struct {
+	apiKey     string
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+	cache      Cache
+	ttl        time.Duration
+}
+
+// Option configures a Client created via NewClient or InitFromEnv.
+type Option func(*Client)
+
+// WithCache sets the Cache consulted before every network request.
+func WithCache(c Cache) Option {
+	return func(client *Client) {
+		client.cache = c
+	}
+}
+
+// WithTTL overrides how long successful responses are cached for.
+func WithTTL(d time.Duration) Option {
+	return func(client *Client) {
+		client.ttl = d
+	}
+}
+
+// WithHTTPClient overrides the *http.Client used to talk to OMDb, e.g. to
+// install custom timeouts, transports or instrumentation.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = hc
+	}
+}
+
+// WithBaseURL overrides the OMDb endpoint, useful for pointing at a mock
+// server in tests or a self-hosted mirror.
+func WithBaseURL(u string) Option {
+	return func(client *Client) {
+		client.baseURL = u
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(client *Client) {
+		client.userAgent = ua
+	}
+}
+
+// NewClient builds a Client for the given OMDb API key.
+func NewClient(apiKey string, opts ...Option) *Client {
+	client := &Client{
+		apiKey:     apiKey,
+		baseURL:    defaultBaseURL,
+		httpClient: http.DefaultClient,
+		ttl:        defaultTTL,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// InitFromEnv builds a Client the way Init used to: it loads OMDB_API_KEY
+// from a ".env" file in the working directory. Unlike the old Init, it
+// returns an error instead of calling log.Fatalf so library consumers can
+// decide how to handle a missing .env themselves.
+func InitFromEnv(opts ...Option) (*Client, error) {
+	if err := godotenv.Load(".env"); err != nil {
+		return nil, fmt.Errorf("loading .env file: %w", err)
+	}
+	return NewClient(os.Getenv("OMDB_API_KEY"), opts...), nil
+}
+
+// QueryData is the type to create the search query
+type QueryData struct {
+	Title      string
+	Year       string
+	ImdbId     string
+	SearchType string
+	// Season and Episode narrow a "title"/"id" lookup down to a single
+	// episode of a series, e.g. Season: "1", Episode: "3".
+	Season  string
+	Episode string
+	// Page selects a page of Search results (10 results per page, OMDb
+	// caps at 10 pages). Zero means "unset", which OMDb treats as page 1.
+	Page int
+}
+
+//EpisodeResult is the result struct of a specific season/episode search
+type EpisodeResult struct {
+	Title      string
+	Released   string
+	Season     string
+	Episode    string
+	ImdbRating string
+	ImdbID     string
+	SeriesID   string
+	Response   string
+	Error      string
+}
+
+//SearchResult is the type for the search results
+type SearchResult struct {
+	Title  string
+	Year   string
+	ImdbID string
+	Type   string
+}
+
+//SearchResponse is the struct of the response in a search
+type SearchResponse struct {
+	Search       []SearchResult
+	Response     string
+	Error        string
+	TotalResults int `json:"totalResults,string"`
+}
+
+//MovieResult is the result struct of an specific movie search
+type MovieResult struct {
+	Title             string
+	Year              string
+	Rated             string
+	Released          string
+	Runtime           string
+	Genre             string
+	Director          string
+	Writer            string
+	Actors            string
+	Plot              string
+	Language          string
+	Country           string
+	Awards            string
+	Poster            string
+	Metascore         string
+	ImdbRating        string
+	ImdbVotes         string
+	ImdbID            string
+	Type              string
+	TomatoMeter       string
+	TomatoImage       string
+	TomatoRating      string
+	TomatoReviews     string
+	TomatoFresh       string
+	TomatoRotten      string
+	TomatoConsensus   string
+	TomatoUserMeter   string
+	TomatoUserRating  string
+	TomatoUserReviews string
+	TomatoURL         string
+	DVD               string
+	BoxOffice         string
+	Production        string
+	Website           string
+	Ratings           []Rating
+	Response          string
+	Error             string
+}
+
+// Rating is one entry of MovieResult.Ratings, e.g. {Source: "Rotten Tomatoes", Value: "93%"}.
+type Rating struct {
+	Source string
+	Value  string
+}
+
+// RatingBySource looks up a MovieResult's rating by its source name, e.g.
+// "Internet Movie Database", "Rotten Tomatoes" or "Metacritic".
+func (mr MovieResult) RatingBySource(src string) (Rating, bool) {
+	for _, r := range mr.Ratings {
+		if r.Source == src {
+			return r, true
+		}
+	}
+	return Rating{}, false
+}
+
+//Search for movies given a Title and year, Year is optional you can leave it empty
+func (api *Client) Search(query *QueryData) (*SearchResponse, error) {
+	return api.SearchContext(context.Background(), query)
+}
+
+//SearchContext is Search with a caller-supplied context, e.g. to attach a
+//per-request deadline.
+func (api *Client) SearchContext(ctx context.Context, query *QueryData) (*SearchResponse, error) {
+	body, err := api.requestAPI(ctx, "search", query)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(SearchResponse)
+	err = json.Unmarshal(body, r)
+
+	if err != nil {
+		return nil, err
+	}
+	if r.Response == "False" {
+		return r, apiError(r.Error)
+	}
+
+	return r, nil
+}
+
+// maxSearchPages is the highest page OMDb will serve for a single search
+// (10 pages * 10 results/page = 100 total results).
+const maxSearchPages = 10
+
+//SearchAll walks every page of a Search query (OMDb caps at 10 pages of 10
+//results each) and returns the concatenated results. It honors ctx
+//cancellation between pages and returns whatever was gathered so far
+//alongside the error if a page fails or ctx is canceled.
+func (api *Client) SearchAll(ctx context.Context, query *QueryData) ([]SearchResult, error) {
+	var all []SearchResult
+
+	q := *query
+	for page := 1; page <= maxSearchPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return all, err
+		}
+
+		q.Page = page
+		resp, err := api.SearchContext(ctx, &q)
+		if err != nil {
+			return all, err
+		}
+
+		all = append(all, resp.Search...)
+		if len(all) >= resp.TotalResults {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+//MovieByTitle returns a MovieResult given Title
+func (api *Client) MovieByTitle(query *QueryData) (*MovieResult, error) {
+	return api.MovieByTitleContext(context.Background(), query)
+}
+
+//MovieByTitleContext is MovieByTitle with a caller-supplied context, e.g. to
+//attach a per-request deadline.
+func (api *Client) MovieByTitleContext(ctx context.Context, query *QueryData) (*MovieResult, error) {
+	body, err := api.requestAPI(ctx, "title", query)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(MovieResult)
+	err = json.Unmarshal(body, r)
+
+	if err != nil {
+		return nil, err
+	}
+	if r.Response == "False" {
+		return r, apiError(r.Error)
+	}
+	return r, nil
+}
+
+//MovieByImdbID returns a MovieResult given a ImdbID ex:"tt2015381"
+func (api *Client) MovieByImdbID(id string) (*MovieResult, error) {
+	return api.MovieByImdbIDContext(context.Background(), id)
+}
+
+//MovieByImdbIDContext is MovieByImdbID with a caller-supplied context, e.g.
+//to attach a per-request deadline.
+func (api *Client) MovieByImdbIDContext(ctx context.Context, id string) (*MovieResult, error) {
+	body, err := api.requestAPI(ctx, "id", &QueryData{ImdbId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(MovieResult)
+	err = json.Unmarshal(body, r)
+
+	if err != nil {
+		return nil, err
+	}
+	if r.Response == "False" {
+		return r, apiError(r.Error)
+	}
+	return r, nil
+}
+
+//EpisodeByImdbID returns a single episode's EpisodeResult given a series
+//ImdbID plus a Season and Episode on query, ex: &QueryData{ImdbId: "tt0903747", Season: "1", Episode: "1"}
+func (api *Client) EpisodeByImdbID(query *QueryData) (*EpisodeResult, error) {
+	return api.EpisodeByImdbIDContext(context.Background(), query)
+}
+
+//EpisodeByImdbIDContext is EpisodeByImdbID with a caller-supplied context,
+//e.g. to attach a per-request deadline.
+func (api *Client) EpisodeByImdbIDContext(ctx context.Context, query *QueryData) (*EpisodeResult, error) {
+	body, err := api.requestAPI(ctx, "id", query)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(EpisodeResult)
+	err = json.Unmarshal(body, r)
+
+	if err != nil {
+		return nil, err
+	}
+	if r.Response == "False" {
+		return r, apiError(r.Error)
+	}
+	return r, nil
+}
+
+// helper function to call the API
+// param: apiCategory refers to which API we are calling. Can be "search", "title" or "id"
+// Depending on that value, we will search by "t" or "s" or "i"
+// param: query carries the fields relevant to apiCategory; unused fields are ignored
+func (api *Client) requestAPI(ctx context.Context, apiCategory string, query *QueryData) (body []byte, err error) {
+	var URL *url.URL
+	var urlAddress = ""
+	URL, err = url.Parse(api.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checking for invalid category
+	if query.SearchType != "" {
+		if query.SearchType != MovieSearch &&
+			query.SearchType != SeriesSearch &&
+			query.SearchType != EpisodeSearch {
+			return nil, apiError("Invalid search category- " + query.SearchType)
+		}
+	}
+	URL.Path += "/"
+	parameters := url.Values{}
+
+	switch apiCategory {
+	case "search":
+		parameters.Add("s", query.Title)
+		parameters.Add("y", query.Year)
+		parameters.Add("type", query.SearchType)
+		if query.Page > 0 {
+			parameters.Add("page", strconv.Itoa(query.Page))
+		}
+	case "title":
+		parameters.Add("t", query.Title)
+		parameters.Add("y", query.Year)
+		parameters.Add("type", query.SearchType)
+		parameters.Add("plot", plot)
+		parameters.Add("tomatoes", tomatoes)
+		addEpisodeParams(parameters, query)
+	case "id":
+		parameters.Add("i", query.ImdbId)
+		parameters.Add("plot", plot)
+		parameters.Add("tomatoes", tomatoes)
+		addEpisodeParams(parameters, query)
+	}
+
+	// The cache key is derived from the request params only, so that
+	// identical lookups hit the cache regardless of which key issued them.
+	key := apiCategory + "?" + parameters.Encode()
+	if api.cache != nil {
+		if cached, ok := api.cache.Get(key); ok {
+			return cached, nil
+		}
+	}
+
+	parameters.Add("apikey", api.apiKey)
+	URL.RawQuery = parameters.Encode()
+	urlAddress = URL.String()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", urlAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if api.userAgent != "" {
+		req.Header.Set("User-Agent", api.userAgent)
+	}
+
+	res, err := api.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res.StatusCode, body)
+	}
+
+	if api.cache != nil {
+		api.cache.Set(key, body, api.ttlFor(body))
+	}
+
+	return body, nil
+}
+
+// newAPIError builds an APIError for a non-200 OMDb response. OMDb answers
+// both a bad/missing API key and a daily rate limit with HTTP 401 and a JSON
+// Error message, so the message (when present) is run through the same
+// apiError classification as the Response:"False" path rather than trusting
+// the transport status code, and only the bare status is used as a fallback.
+func newAPIError(statusCode int, body []byte) error {
+	var probe struct{ Error string }
+	if err := json.Unmarshal(body, &probe); err == nil && probe.Error != "" {
+		return apiError(probe.Error)
+	}
+	return &APIError{StatusCode: statusCode, Message: fmt.Sprintf("Status Code %d received from IMDB", statusCode)}
+}
+
+// ttlFor picks the TTL a response should be cached for: a negative response
+// ("Movie not found!", bad title, etc.) is cached for a much shorter window
+// than a successful lookup so typos don't stick around but repeated hits
+// against the same bad query still get absorbed.
+func (api *Client) ttlFor(body []byte) time.Duration {
+	var probe struct {
+		Response string
+	}
+	if err := json.Unmarshal(body, &probe); err == nil && probe.Response == "False" {
+		return negativeTTL
+	}
+	return api.ttl
+}
+
+// APIError is returned for any failed OMDb lookup, whether OMDb rejected the
+// request outright (bad/missing API key, rate limit) or answered with
+// "Response": "False" (movie not found, bad search category, ...). StatusCode
+// is the HTTP status a REST frontend should map the error onto.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// apiError wraps an OMDb "Error" message with the status code a REST
+// frontend should answer with, inferred from OMDb's (undocumented, string)
+// error vocabulary.
+func apiError(msg string) *APIError {
+	switch {
+	case strings.Contains(msg, "not found"), strings.Contains(msg, "Incorrect IMDb ID"):
+		return &APIError{StatusCode: http.StatusNotFound, Message: msg}
+	case strings.Contains(msg, "API key"):
+		return &APIError{StatusCode: http.StatusUnauthorized, Message: msg}
+	case strings.Contains(msg, "Request limit reached"):
+		return &APIError{StatusCode: http.StatusTooManyRequests, Message: msg}
+	default:
+		return &APIError{StatusCode: http.StatusBadRequest, Message: msg}
+	}
+}
+
+// addEpisodeParams forwards the Season/Episode narrowing fields of query,
+// when present, onto parameters.
+func addEpisodeParams(parameters url.Values, query *QueryData) {
+	if query.Season != "" {
+		parameters.Add("Season", query.Season)
+	}
+	if query.Episode != "" {
+		parameters.Add("Episode", query.Episode)
+	}
+}
+
+//Stringer Interface for MovieResult
+func (mr MovieResult) String() string {
+	return fmt.Sprintf("#%s: %s (%s)", mr.ImdbID, mr.Title, mr.Year)
+}
+
+//Stringer Interface for SearchResult
+func (sr SearchResult) String() string {
+	return fmt.Sprintf("#%s: %s (%s) Type: %s", sr.ImdbID, sr.Title, sr.Year, sr.Type)
+}
+
+//Stringer Interface for EpisodeResult
+func (er EpisodeResult) String() string {
+	return fmt.Sprintf("#%s: %s S%sE%s", er.ImdbID, er.Title, er.Season, er.Episode)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newPagedSearchServer serves totalResults results, resultsPerPage per page,
+// failing the page in failOnPage (1-indexed, 0 means never fail).
+func newPagedSearchServer(t *testing.T, totalResults, failOnPage int) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			fmt.Sscanf(p, "%d", &page)
+		}
+
+		if failOnPage != 0 && page == failOnPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		start := (page - 1) * resultsPerPage
+		end := start + resultsPerPage
+		if end > totalResults {
+			end = totalResults
+		}
+
+		var results []SearchResult
+		for i := start; i < end; i++ {
+			results = append(results, SearchResult{ImdbID: fmt.Sprintf("tt%d", i), Title: fmt.Sprintf("Movie %d", i)})
+		}
+
+		json.NewEncoder(w).Encode(SearchResponse{
+			Search:       results,
+			Response:     "True",
+			TotalResults: totalResults,
+		})
+	}))
+}
+
+const resultsPerPage = 10
+
+func TestSearchAllStopsAtTotalResults(t *testing.T) {
+	srv := newPagedSearchServer(t, 25, 0)
+	defer srv.Close()
+
+	api := NewClient("test-key", WithBaseURL(srv.URL))
+
+	got, err := api.SearchAll(context.Background(), &QueryData{Title: "batman"})
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+	if len(got) != 25 {
+		t.Errorf("SearchAll() returned %d results, want 25", len(got))
+	}
+}
+
+func TestSearchAllStopsAtPageCap(t *testing.T) {
+	// More results than maxSearchPages*resultsPerPage could ever deliver;
+	// SearchAll must bail out after maxSearchPages rather than looping forever.
+	srv := newPagedSearchServer(t, 1000, 0)
+	defer srv.Close()
+
+	api := NewClient("test-key", WithBaseURL(srv.URL))
+
+	got, err := api.SearchAll(context.Background(), &QueryData{Title: "batman"})
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+	if want := maxSearchPages * resultsPerPage; len(got) != want {
+		t.Errorf("SearchAll() returned %d results, want %d", len(got), want)
+	}
+}
+
+func TestSearchAllReturnsPartialResultsOnMidPageFailure(t *testing.T) {
+	srv := newPagedSearchServer(t, 30, 2)
+	defer srv.Close()
+
+	api := NewClient("test-key", WithBaseURL(srv.URL))
+
+	got, err := api.SearchAll(context.Background(), &QueryData{Title: "batman"})
+	if err == nil {
+		t.Fatalf("SearchAll() error = nil, want error from failed page")
+	}
+	if len(got) != resultsPerPage {
+		t.Errorf("SearchAll() returned %d results, want %d from the first successful page", len(got), resultsPerPage)
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// releasedLayout is the date format OMDb uses for MovieResult.Released, e.g. "14 Oct 1994".
+const releasedLayout = "02 Jan 2006"
+
+// naValue is the sentinel OMDb uses in place of any field it has no data for.
+const naValue = "N/A"
+
+// RuntimeMinutes parses MovieResult.Runtime (e.g. "142 min") into minutes.
+// It returns ok=false for "N/A" or an unparsable value.
+func (mr MovieResult) RuntimeMinutes() (int, bool) {
+	fields := strings.Fields(mr.Runtime)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	minutes, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, false
+	}
+	return minutes, true
+}
+
+// ImdbRatingFloat parses MovieResult.ImdbRating (e.g. "8.8") into a float64.
+// It returns ok=false for "N/A" or an unparsable value.
+func (mr MovieResult) ImdbRatingFloat() (float64, bool) {
+	if mr.ImdbRating == "" || mr.ImdbRating == naValue {
+		return 0, false
+	}
+	rating, err := strconv.ParseFloat(mr.ImdbRating, 64)
+	if err != nil {
+		return 0, false
+	}
+	return rating, true
+}
+
+// BoxOfficeUSD parses MovieResult.BoxOffice (e.g. "$28,341,469") into whole
+// US dollars. It returns ok=false for "N/A" or an unparsable value.
+func (mr MovieResult) BoxOfficeUSD() (int64, bool) {
+	cleaned := strings.NewReplacer("$", "", ",", "").Replace(mr.BoxOffice)
+	if cleaned == "" || cleaned == naValue {
+		return 0, false
+	}
+	amount, err := strconv.ParseInt(cleaned, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return amount, true
+}
+
+// ReleasedTime parses MovieResult.Released (e.g. "14 Oct 1994") into a time.Time.
+// It returns ok=false for "N/A" or an unparsable value.
+func (mr MovieResult) ReleasedTime() (time.Time, bool) {
+	if mr.Released == "" || mr.Released == naValue {
+		return time.Time{}, false
+	}
+	released, err := time.Parse(releasedLayout, mr.Released)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return released, true
+}
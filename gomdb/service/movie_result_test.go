@@ -0,0 +1,128 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMovieResultRuntimeMinutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime string
+		want    int
+		wantOk  bool
+	}{
+		{"well formed", "142 min", 142, true},
+		{"not available", naValue, 0, false},
+		{"empty", "", 0, false},
+		{"malformed", "a long time", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := MovieResult{Runtime: tt.runtime}
+			got, ok := mr.RuntimeMinutes()
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("RuntimeMinutes() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestMovieResultImdbRatingFloat(t *testing.T) {
+	tests := []struct {
+		name       string
+		imdbRating string
+		want       float64
+		wantOk     bool
+	}{
+		{"well formed", "8.8", 8.8, true},
+		{"not available", naValue, 0, false},
+		{"empty", "", 0, false},
+		{"malformed", "great", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := MovieResult{ImdbRating: tt.imdbRating}
+			got, ok := mr.ImdbRatingFloat()
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("ImdbRatingFloat() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestMovieResultBoxOfficeUSD(t *testing.T) {
+	tests := []struct {
+		name      string
+		boxOffice string
+		want      int64
+		wantOk    bool
+	}{
+		{"well formed", "$28,341,469", 28341469, true},
+		{"not available", naValue, 0, false},
+		{"empty", "", 0, false},
+		{"malformed", "lots of money", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := MovieResult{BoxOffice: tt.boxOffice}
+			got, ok := mr.BoxOfficeUSD()
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("BoxOfficeUSD() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestMovieResultReleasedTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		released string
+		want     time.Time
+		wantOk   bool
+	}{
+		{"well formed", "14 Oct 1994", time.Date(1994, time.October, 14, 0, 0, 0, 0, time.UTC), true},
+		{"not available", naValue, time.Time{}, false},
+		{"empty", "", time.Time{}, false},
+		{"malformed", "sometime last year", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := MovieResult{Released: tt.released}
+			got, ok := mr.ReleasedTime()
+			if !got.Equal(tt.want) || ok != tt.wantOk {
+				t.Errorf("ReleasedTime() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestMovieResultRatingBySource(t *testing.T) {
+	mr := MovieResult{Ratings: []Rating{
+		{Source: "Internet Movie Database", Value: "8.8/10"},
+		{Source: "Rotten Tomatoes", Value: "93%"},
+	}}
+
+	tests := []struct {
+		name   string
+		src    string
+		want   Rating
+		wantOk bool
+	}{
+		{"found", "Rotten Tomatoes", Rating{Source: "Rotten Tomatoes", Value: "93%"}, true},
+		{"not found", "Metacritic", Rating{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := mr.RatingBySource(tt.src)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("RatingBySource(%q) = %v, %v, want %v, %v", tt.src, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}